@@ -0,0 +1,240 @@
+package core
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dpkgStatusFile = "/var/lib/dpkg/status"
+	aptListsDir    = "/var/lib/apt/lists"
+)
+
+func init() {
+	RegisterProvider(&aptProvider{})
+}
+
+// aptProvider reads dpkg's status file for installed packages and the
+// cached Packages files under /var/lib/apt/lists (as left behind by the
+// last `apt update`) for what's available, so no root privileges or
+// `apt-get` invocation is required.
+type aptProvider struct{}
+
+func (p *aptProvider) Name() string { return "apt" }
+
+func (p *aptProvider) Available() bool {
+	_, err := os.Stat(dpkgStatusFile)
+	return err == nil
+}
+
+func (p *aptProvider) Query() (ProviderData, error) {
+	installed, err := readDpkgStatus(dpkgStatusFile)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	available, lastSync, err := readAptLists(aptListsDir)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	data := ProviderData{Name: p.Name(), Installed: len(installed), LastSync: lastSync}
+	for name, localVer := range installed {
+		entry, ok := available[name]
+		if !ok {
+			// Not in any cached Packages list: likely a PPA/local .deb, or
+			// a package dropped from the mirrors since the last apt update.
+			data.Missing++
+			continue
+		}
+		if VerCmp(localVer, entry.version) < 0 {
+			data.OutOfDate++
+			data.Pending++
+			data.Upgrades = append(data.Upgrades, Package{
+				Name:          name,
+				Repository:    "apt",
+				LocalVersion:  localVer,
+				RemoteVersion: entry.version,
+				Status:        StatusRepo,
+				DownloadSize:  entry.downloadSize,
+				InstalledSize: entry.installedSize,
+			})
+		}
+	}
+	return data, nil
+}
+
+// readDpkgStatus parses dpkg's RFC822-ish status file into name ->
+// installed version, skipping anything not reported "install ok
+// installed".
+func readDpkgStatus(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	packages := make(map[string]string)
+	var name, version, status string
+	flush := func() {
+		if name != "" && status == "install ok installed" {
+			packages[name] = version
+		}
+		name, version, status = "", "", ""
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "Package: "):
+			name = strings.TrimPrefix(line, "Package: ")
+		case strings.HasPrefix(line, "Version: "):
+			version = strings.TrimPrefix(line, "Version: ")
+		case strings.HasPrefix(line, "Status: "):
+			status = strings.TrimPrefix(line, "Status: ")
+		}
+	}
+	flush()
+	return packages, scanner.Err()
+}
+
+// readAptLists scans every cached *_Packages(.gz|.bz2|.xz) file under
+// /var/lib/apt/lists for the newest version of each package, and returns
+// the mtime of the most recently refreshed list as the sync time.
+func readAptLists(dir string) (map[string]aptEntry, time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	packages := make(map[string]aptEntry)
+	var lastSync time.Time
+	for _, e := range entries {
+		if e.IsDir() || !strings.Contains(e.Name(), "_Packages") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if info, err := e.Info(); err == nil && info.ModTime().After(lastSync) {
+			lastSync = info.ModTime()
+		}
+		if err := parsePackagesFile(path, packages); err != nil {
+			continue
+		}
+	}
+	return packages, lastSync, nil
+}
+
+// aptEntry is what readAptLists knows about an available package.
+// Installed-Size is reported in KiB by apt, so it's converted to bytes
+// to match Size (and DownloadSize/InstalledSize generally).
+type aptEntry struct {
+	version       string
+	downloadSize  int64
+	installedSize int64
+}
+
+func parsePackagesFile(path string, into map[string]aptEntry) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := decompressReader(path, f)
+	if err != nil {
+		return err
+	}
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	var name string
+	var entry aptEntry
+	flush := func() {
+		if name == "" {
+			return
+		}
+		// A package can appear in multiple Packages files (e.g. several
+		// pockets of the same suite); keep the newest version seen.
+		if existing, ok := into[name]; !ok || VerCmp(existing.version, entry.version) < 0 {
+			into[name] = entry
+		}
+		name, entry = "", aptEntry{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "Package: "):
+			name = strings.TrimPrefix(line, "Package: ")
+		case strings.HasPrefix(line, "Version: "):
+			entry.version = strings.TrimPrefix(line, "Version: ")
+		case strings.HasPrefix(line, "Size: "):
+			entry.downloadSize, _ = strconv.ParseInt(strings.TrimPrefix(line, "Size: "), 10, 64)
+		case strings.HasPrefix(line, "Installed-Size: "):
+			kib, _ := strconv.ParseInt(strings.TrimPrefix(line, "Installed-Size: "), 10, 64)
+			entry.installedSize = kib * 1024
+		}
+	}
+	flush()
+	return scanner.Err()
+}
+
+// decompressReader wraps f according to path's extension. gzip and bzip2
+// are handled with the standard library; xz has no stdlib decompressor,
+// so we shell out to the system `xz` binary the same way apt itself
+// relies on external tools for less common compression formats.
+func decompressReader(path string, f *os.File) (io.Reader, error) {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		return gzip.NewReader(f)
+	case strings.HasSuffix(path, ".bz2"):
+		return bzip2.NewReader(f), nil
+	case strings.HasSuffix(path, ".xz"):
+		cmd := exec.Command("xz", "-dc", path)
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &xzReader{ReadCloser: out, cmd: cmd}, nil
+	default:
+		return f, nil
+	}
+}
+
+// xzReader wraps the stdout pipe of a running `xz -dc` child, waiting on
+// the process at Close so it doesn't linger as a zombie once its output
+// has been fully read and the pipe closed.
+type xzReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (x *xzReader) Close() error {
+	pipeErr := x.ReadCloser.Close()
+	if err := x.cmd.Wait(); err != nil {
+		return err
+	}
+	return pipeErr
+}
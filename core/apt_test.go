@@ -0,0 +1,93 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePackagesFile(t *testing.T) {
+	packages := "Package: foo\n" +
+		"Version: 1.0-1\n" +
+		"Size: 1024\n" +
+		"Installed-Size: 10\n" +
+		"\n" +
+		"Package: foo\n" +
+		"Version: 2.0-1\n" +
+		"Size: 2048\n" +
+		"Installed-Size: 20\n" +
+		"\n" +
+		"Package: bar\n" +
+		"Version: 1.0-1\n" +
+		"Size: 512\n" +
+		"Installed-Size: 5\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example_Packages.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(packages)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	into := make(map[string]aptEntry)
+	if err := parsePackagesFile(path, into); err != nil {
+		t.Fatalf("parsePackagesFile returned error: %v", err)
+	}
+
+	// Two stanzas for "foo": the newer version should win.
+	foo, ok := into["foo"]
+	if !ok {
+		t.Fatal("expected entry for foo")
+	}
+	if foo.version != "2.0-1" || foo.downloadSize != 2048 || foo.installedSize != 20*1024 {
+		t.Errorf("foo = %+v, want version 2.0-1, downloadSize 2048, installedSize 20480", foo)
+	}
+
+	bar, ok := into["bar"]
+	if !ok {
+		t.Fatal("expected entry for bar")
+	}
+	if bar.version != "1.0-1" || bar.downloadSize != 512 {
+		t.Errorf("bar = %+v, want version 1.0-1, downloadSize 512", bar)
+	}
+}
+
+func TestReadDpkgStatus(t *testing.T) {
+	status := "Package: foo\n" +
+		"Status: install ok installed\n" +
+		"Version: 1.0-1\n" +
+		"\n" +
+		"Package: bar\n" +
+		"Status: deinstall ok config-files\n" +
+		"Version: 2.0-1\n" +
+		"\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "status")
+	if err := os.WriteFile(path, []byte(status), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	packages, err := readDpkgStatus(path)
+	if err != nil {
+		t.Fatalf("readDpkgStatus returned error: %v", err)
+	}
+	if len(packages) != 1 {
+		t.Fatalf("len(packages) = %d, want 1 (only fully installed packages)", len(packages))
+	}
+	if packages["foo"] != "1.0-1" {
+		t.Errorf("foo = %q, want 1.0-1", packages["foo"])
+	}
+	if _, ok := packages["bar"]; ok {
+		t.Error("bar is deinstalled and should not be reported as installed")
+	}
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	aurRPCURL      = "https://aur.archlinux.org/rpc/?v=5"
+	aurBatchSize   = 150 // stay comfortably under the AUR RPC URL length limit
+	aurHTTPTimeout = 15 * time.Second
+)
+
+func init() {
+	RegisterProvider(&aurProvider{client: &http.Client{Timeout: aurHTTPTimeout}})
+}
+
+// aurProvider treats every package installed locally but absent from the
+// pacman sync databases as a "foreign" (AUR or manually built) package,
+// and checks it against the AUR RPC for a newer version.
+type aurProvider struct {
+	client *http.Client
+}
+
+func (p *aurProvider) Name() string { return "aur" }
+
+// Available mirrors pacmanProvider.Available: the AUR provider is only
+// meaningful on a system that also has a pacman local database to diff
+// against.
+func (p *aurProvider) Available() bool {
+	return (&pacmanProvider{}).Available()
+}
+
+func (p *aurProvider) Query() (ProviderData, error) {
+	local, err := readLocalPackages(pacmanLocalDir)
+	if err != nil {
+		return ProviderData{}, err
+	}
+	sync, _, err := readSyncDatabases(pacmanSyncDir)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	foreign := make([]string, 0)
+	for name := range local {
+		if _, inRepo := sync[name]; !inRepo {
+			foreign = append(foreign, name)
+		}
+	}
+
+	data := ProviderData{Name: p.Name()}
+	infos, err := p.infoBatched(foreign)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	seen := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		seen[info.Name] = true
+		localVer := local[info.Name].version
+		if VerCmp(localVer, info.Version) < 0 {
+			data.OutOfDate++
+			data.Pending++
+			data.Upgrades = append(data.Upgrades, Package{
+				Name:          info.Name,
+				Repository:    "aur",
+				LocalVersion:  localVer,
+				RemoteVersion: info.Version,
+				Status:        StatusAUR,
+			})
+		}
+	}
+	for _, name := range foreign {
+		if !seen[name] {
+			// Installed, foreign, and unknown to the AUR: built locally
+			// or removed upstream.
+			data.Missing++
+		}
+	}
+	data.Installed = len(foreign)
+	return data, nil
+}
+
+type aurPackageInfo struct {
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+}
+
+type aurRPCResponse struct {
+	Results []aurPackageInfo `json:"results"`
+}
+
+// infoBatched queries the AUR RPC "info" method for every name, chunking
+// requests so the query string never grows large enough to be rejected.
+func (p *aurProvider) infoBatched(names []string) ([]aurPackageInfo, error) {
+	var all []aurPackageInfo
+	for start := 0; start < len(names); start += aurBatchSize {
+		end := start + aurBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		infos, err := p.info(names[start:end])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, infos...)
+	}
+	return all, nil
+}
+
+func (p *aurProvider) info(names []string) ([]aurPackageInfo, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(aurRPCURL)
+	b.WriteString("&type=info")
+	for _, name := range names {
+		b.WriteString("&arg[]=")
+		b.WriteString(url.QueryEscape(name))
+	}
+
+	resp, err := p.client.Get(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("aur: querying rpc: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("aur: rpc returned %s", resp.Status)
+	}
+
+	var parsed aurRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("aur: decoding rpc response: %w", err)
+	}
+	return parsed.Results, nil
+}
@@ -2,21 +2,41 @@ package core
 
 import "time"
 
+// CoreData is the aggregate update status across every active provider,
+// as displayed by ui.DisplayCoreData.
 type CoreData struct {
-	DaysSinceUpdate int
+	DaysSinceUpdate        int
 	TotalPackagesInstalled int
-	PendingUpdates int
+	PendingUpdates         int
+	Providers              []ProviderData
 }
 
 func DaysSince(t time.Time) int {
 	return int(time.Since(t).Hours() / 24)
 }
 
+// GetCoreDate queries every registered, available PackageManager and
+// aggregates the results. DaysSinceUpdate reflects the most recently
+// synced provider database rather than a fixed point in time.
 func GetCoreDate() CoreData {
-	lastUpdate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.Local)
-	return CoreData{
-		DaysSinceUpdate: DaysSince(lastUpdate),
-		TotalPackagesInstalled: 150,
-		PendingUpdates: 5,
+	var data CoreData
+	var lastSync time.Time
+
+	for _, provider := range ActiveProviders() {
+		pd, err := provider.Query()
+		if err != nil {
+			continue
+		}
+		data.Providers = append(data.Providers, pd)
+		data.TotalPackagesInstalled += pd.Installed
+		data.PendingUpdates += pd.Pending
+		if pd.LastSync.After(lastSync) {
+			lastSync = pd.LastSync
+		}
+	}
+
+	if !lastSync.IsZero() {
+		data.DaysSinceUpdate = DaysSince(lastSync)
 	}
+	return data
 }
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pacmanLocalDir = "/var/lib/pacman/local"
+	pacmanSyncDir  = "/var/lib/pacman/sync"
+)
+
+func init() {
+	RegisterProvider(&pacmanProvider{})
+}
+
+// pacmanProvider reads pacman's local package database directly rather
+// than shelling out to pacman, so upkg stays usable without a live pacman
+// lock and without parsing localized command output.
+type pacmanProvider struct{}
+
+func (p *pacmanProvider) Name() string { return "pacman" }
+
+func (p *pacmanProvider) Available() bool {
+	info, err := os.Stat(pacmanLocalDir)
+	return err == nil && info.IsDir()
+}
+
+func (p *pacmanProvider) Query() (ProviderData, error) {
+	local, err := readLocalPackages(pacmanLocalDir)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	sync, lastSync, err := readSyncDatabases(pacmanSyncDir)
+	if err != nil {
+		return ProviderData{}, err
+	}
+
+	data := ProviderData{Name: p.Name(), Installed: len(local), LastSync: lastSync}
+	for name, pkg := range local {
+		// reason 1 is "installed as a dependency" in pacman's local desc
+		// files; an orphan is one of those with nothing left requiring it.
+		if pkg.reason == 1 && len(pkg.requiredBy) == 0 {
+			data.Orphaned++
+		}
+
+		entry, inRepo := sync[name]
+		if !inRepo {
+			data.Missing++
+			continue
+		}
+		if VerCmp(pkg.version, entry.version) < 0 {
+			data.OutOfDate++
+			data.Pending++
+			data.Upgrades = append(data.Upgrades, Package{
+				Name:          name,
+				Repository:    entry.repo,
+				LocalVersion:  pkg.version,
+				RemoteVersion: entry.version,
+				Status:        StatusRepo,
+				DownloadSize:  entry.downloadSize,
+				InstalledSize: entry.installedSize,
+			})
+		}
+	}
+	return data, nil
+}
+
+type localPackage struct {
+	version    string
+	reason     int
+	requiredBy []string
+}
+
+// readLocalPackages returns name -> local package info for every package
+// under /var/lib/pacman/local, parsed out of each package's desc file.
+func readLocalPackages(dir string) (map[string]localPackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make(map[string]localPackage, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		name, pkg, err := parseDesc(filepath.Join(dir, e.Name(), "desc"))
+		if err != nil {
+			continue
+		}
+		packages[name] = pkg
+	}
+	return packages, nil
+}
+
+func parseDesc(path string) (name string, pkg localPackage, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", localPackage{}, err
+	}
+	defer f.Close()
+
+	fields, err := parseDescFields(f)
+	if err != nil {
+		return "", localPackage{}, err
+	}
+
+	reason, _ := strconv.Atoi(first(fields["%REASON%"]))
+	pkg = localPackage{
+		version:    first(fields["%VERSION%"]),
+		reason:     reason,
+		requiredBy: fields["%REQUIREDBY%"],
+	}
+	return first(fields["%NAME%"]), pkg, nil
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+type syncEntry struct {
+	repo          string
+	version       string
+	downloadSize  int64
+	installedSize int64
+}
+
+// readSyncDatabases walks every *.db (a tar archive, gzip-compressed or
+// plain) under /var/lib/pacman/sync and returns the newest version
+// known for each package, along with the mtime of the most recently
+// synced database.
+func readSyncDatabases(dir string) (map[string]syncEntry, time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	packages := make(map[string]syncEntry)
+	var lastSync time.Time
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") {
+			continue
+		}
+		repo := strings.TrimSuffix(e.Name(), ".db")
+		path := filepath.Join(dir, e.Name())
+
+		if info, err := e.Info(); err == nil && info.ModTime().After(lastSync) {
+			lastSync = info.ModTime()
+		}
+
+		if err := parseSyncDB(path, repo, packages); err != nil {
+			continue
+		}
+	}
+	return packages, lastSync, nil
+}
+
+func parseSyncDB(path, repo string, into map[string]syncEntry) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		// Modern pacman sync DBs are plain (uncompressed) tars; fall back
+		// to reading the file directly.
+		if _, seekErr := f.Seek(0, 0); seekErr != nil {
+			return seekErr
+		}
+		return parseSyncTar(f, repo, into)
+	}
+	defer gz.Close()
+	return parseSyncTar(gz, repo, into)
+}
+
+func parseSyncTar(r io.Reader, repo string, into map[string]syncEntry) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		if !strings.HasSuffix(hdr.Name, "/desc") {
+			continue
+		}
+		fields, err := parseDescFields(tr)
+		if err != nil {
+			continue
+		}
+		name := first(fields["%NAME%"])
+		entry := syncEntry{
+			repo:          repo,
+			version:       first(fields["%VERSION%"]),
+			downloadSize:  parseSize(first(fields["%CSIZE%"])),
+			installedSize: parseSize(first(fields["%ISIZE%"])),
+		}
+		// A package can appear in several sync DBs; keep the newest
+		// version seen.
+		if existing, ok := into[name]; !ok || VerCmp(existing.version, entry.version) < 0 {
+			into[name] = entry
+		}
+	}
+	return nil
+}
+
+// parseDescFields reads a pacman desc file into a map of %FIELD% name to
+// its value lines. Desc files interleave a "%FIELD%" marker line with one
+// or more value lines separated by a blank line; most fields (name,
+// version, sizes) only ever have one value line, but multi-valued fields
+// like %REQUIREDBY% can have several, so every line is kept.
+func parseDescFields(r io.Reader) (map[string][]string, error) {
+	fields := make(map[string][]string)
+	scanner := bufio.NewScanner(r)
+	var field string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "%") && strings.HasSuffix(line, "%") {
+			field = line
+			continue
+		}
+		if line == "" {
+			field = ""
+			continue
+		}
+		if field != "" {
+			fields[field] = append(fields[field], line)
+		}
+	}
+	return fields, scanner.Err()
+}
+
+func parseSize(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDescFields(t *testing.T) {
+	desc := "%NAME%\n" +
+		"foo\n" +
+		"\n" +
+		"%VERSION%\n" +
+		"1.0-1\n" +
+		"\n" +
+		"%REASON%\n" +
+		"1\n" +
+		"\n" +
+		"%REQUIREDBY%\n" +
+		"bar\n" +
+		"baz\n"
+
+	fields, err := parseDescFields(strings.NewReader(desc))
+	if err != nil {
+		t.Fatalf("parseDescFields returned error: %v", err)
+	}
+
+	if got := first(fields["%NAME%"]); got != "foo" {
+		t.Errorf("%%NAME%% = %q, want foo", got)
+	}
+	if got := first(fields["%VERSION%"]); got != "1.0-1" {
+		t.Errorf("%%VERSION%% = %q, want 1.0-1", got)
+	}
+	if got := fields["%REQUIREDBY%"]; len(got) != 2 || got[0] != "bar" || got[1] != "baz" {
+		t.Errorf("%%REQUIREDBY%% = %v, want [bar baz]", got)
+	}
+}
+
+func TestParseDesc(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "desc")
+	desc := "%NAME%\n" +
+		"foo\n" +
+		"\n" +
+		"%VERSION%\n" +
+		"1.0-1\n" +
+		"\n" +
+		"%REASON%\n" +
+		"1\n" +
+		"\n"
+	if err := os.WriteFile(path, []byte(desc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	name, pkg, err := parseDesc(path)
+	if err != nil {
+		t.Fatalf("parseDesc returned error: %v", err)
+	}
+	if name != "foo" {
+		t.Errorf("name = %q, want foo", name)
+	}
+	if pkg.version != "1.0-1" {
+		t.Errorf("version = %q, want 1.0-1", pkg.version)
+	}
+	if pkg.reason != 1 {
+		t.Errorf("reason = %d, want 1", pkg.reason)
+	}
+	if len(pkg.requiredBy) != 0 {
+		t.Errorf("requiredBy = %v, want empty (orphan candidate)", pkg.requiredBy)
+	}
+}
+
+func TestParseSyncTar(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	desc := "%NAME%\n" +
+		"foo\n" +
+		"\n" +
+		"%VERSION%\n" +
+		"2.0-1\n" +
+		"\n" +
+		"%CSIZE%\n" +
+		"1024\n" +
+		"\n" +
+		"%ISIZE%\n" +
+		"2048\n"
+
+	hdr := &tar.Header{Name: "foo-2.0-1/desc", Size: int64(len(desc)), Mode: 0o644}
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(desc)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	into := make(map[string]syncEntry)
+	if err := parseSyncTar(&buf, "core", into); err != nil {
+		t.Fatalf("parseSyncTar returned error: %v", err)
+	}
+
+	entry, ok := into["foo"]
+	if !ok {
+		t.Fatal("expected entry for foo")
+	}
+	if entry.repo != "core" || entry.version != "2.0-1" || entry.downloadSize != 1024 || entry.installedSize != 2048 {
+		t.Errorf("entry = %+v, want {core 2.0-1 1024 2048}", entry)
+	}
+}
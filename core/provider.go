@@ -0,0 +1,86 @@
+package core
+
+import (
+	"sort"
+	"time"
+)
+
+// PackageStatus classifies a single installed package relative to its
+// provider's view of the world.
+type PackageStatus int
+
+const (
+	StatusRepo PackageStatus = iota
+	StatusAUR
+	StatusMissing
+	StatusOrphaned
+	StatusOutOfDate
+)
+
+// Package describes one installed package as seen by a provider.
+type Package struct {
+	Name          string
+	Repository    string
+	LocalVersion  string
+	RemoteVersion string
+	Status        PackageStatus
+	// DownloadSize and InstalledSize are in bytes. A provider that can't
+	// determine a size (e.g. aurProvider, which the AUR RPC doesn't
+	// report) leaves it zero.
+	DownloadSize  int64
+	InstalledSize int64
+}
+
+// ProviderData is the per-provider breakdown aggregated into CoreData.
+type ProviderData struct {
+	Name      string
+	Installed int
+	Pending   int
+	// Missing counts installed packages with no matching entry in this
+	// provider's index at all (removed upstream, or never published).
+	Missing int
+	// Orphaned counts packages that were installed only as a dependency
+	// and are no longer required by anything. Providers that can't
+	// determine this (aptProvider, aurProvider) leave it zero.
+	Orphaned  int
+	OutOfDate int
+	LastSync  time.Time
+	Upgrades  []Package
+}
+
+// PackageManager is implemented by every package-manager backend (pacman,
+// AUR, apt, ...). Providers are expected to be cheap to construct and to
+// report their own availability via Available so upkg can run unmodified
+// across distros.
+type PackageManager interface {
+	// Name identifies the provider in ProviderData and CLI output.
+	Name() string
+	// Available reports whether this provider's backing tool/database is
+	// present on the host.
+	Available() bool
+	// Query inspects the host system and returns this provider's view of
+	// installed packages, pending upgrades, and sync freshness.
+	Query() (ProviderData, error)
+}
+
+var providers = map[string]PackageManager{}
+
+// RegisterProvider makes a PackageManager available to GetCoreDate.
+// Providers call this from their own init() so the registry is populated
+// purely by importing the core package's provider files.
+func RegisterProvider(p PackageManager) {
+	providers[p.Name()] = p
+}
+
+// ActiveProviders returns every registered provider whose Available()
+// check passed, sorted by name for stable output ordering.
+func ActiveProviders() []PackageManager {
+	active := make([]PackageManager, 0, len(providers))
+	for _, p := range providers {
+		if p.Available() {
+			active = append(active, p)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Name() < active[j].Name() })
+	return active
+}
@@ -0,0 +1,33 @@
+package core
+
+// Upgrade is a single pending package upgrade, as surfaced to UI flows
+// such as ui.SelectUpgrades. It drops the provider-internal Status field
+// that Package carries since the UI only ever needs to show and select
+// upgrades, never classify them.
+type Upgrade struct {
+	Name          string
+	Repository    string
+	LocalVersion  string
+	RemoteVersion string
+	DownloadSize  int64
+	InstalledSize int64
+}
+
+// PendingUpgrades flattens every active provider's upgrade list out of
+// CoreData into the simpler view ui.SelectUpgrades consumes.
+func PendingUpgrades(data CoreData) []Upgrade {
+	var upgrades []Upgrade
+	for _, pd := range data.Providers {
+		for _, pkg := range pd.Upgrades {
+			upgrades = append(upgrades, Upgrade{
+				Name:          pkg.Name,
+				Repository:    pkg.Repository,
+				LocalVersion:  pkg.LocalVersion,
+				RemoteVersion: pkg.RemoteVersion,
+				DownloadSize:  pkg.DownloadSize,
+				InstalledSize: pkg.InstalledSize,
+			})
+		}
+	}
+	return upgrades
+}
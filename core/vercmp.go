@@ -0,0 +1,135 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VerCmp compares two package versions using the same algorithm as
+// pacman/libalpm's vercmp: versions are of the form
+// [epoch:]pkgver[-pkgrel], compared epoch first, then pkgver and pkgrel
+// segment by segment, alternating numeric and alphabetic runs. It
+// returns -1, 0, or 1 the way strings.Compare does.
+func VerCmp(a, b string) int {
+	aEpoch, aRest := splitEpoch(a)
+	bEpoch, bRest := splitEpoch(b)
+	if aEpoch != bEpoch {
+		if aEpoch < bEpoch {
+			return -1
+		}
+		return 1
+	}
+
+	aVer, aRel, aHasRel := splitRel(aRest)
+	bVer, bRel, bHasRel := splitRel(bRest)
+
+	if c := compareSegments(aVer, bVer); c != 0 {
+		return c
+	}
+	if !aHasRel || !bHasRel {
+		// Missing pkgrel on either side is treated as equal, matching
+		// alpm's behaviour when comparing against a pkgver-only string.
+		return 0
+	}
+	return compareSegments(aRel, bRel)
+}
+
+func splitEpoch(v string) (int, string) {
+	if i := strings.IndexByte(v, ':'); i >= 0 {
+		epoch, err := strconv.Atoi(v[:i])
+		if err == nil {
+			return epoch, v[i+1:]
+		}
+	}
+	return 0, v
+}
+
+func splitRel(v string) (ver, rel string, hasRel bool) {
+	if i := strings.LastIndexByte(v, '-'); i >= 0 {
+		return v[:i], v[i+1:], true
+	}
+	return v, "", false
+}
+
+// compareSegments walks two version strings alternating between runs of
+// digits and runs of non-digits, comparing each run in turn.
+func compareSegments(a, b string) int {
+	for len(a) > 0 || len(b) > 0 {
+		// Drop matching separator runs (anything that's not alnum).
+		a = strings.TrimLeft(a, ".-_+")
+		b = strings.TrimLeft(b, ".-_+")
+
+		aNum := leadingDigits(a)
+		bNum := leadingDigits(b)
+
+		switch {
+		case aNum != "" && bNum != "":
+			if c := compareNumeric(aNum, bNum); c != 0 {
+				return c
+			}
+			a = a[len(aNum):]
+			b = b[len(bNum):]
+		case aNum != "" && bNum == "":
+			// Numeric segments are always newer than alphabetic ones.
+			return 1
+		case aNum == "" && bNum != "":
+			return -1
+		default:
+			aAlpha := leadingAlpha(a)
+			bAlpha := leadingAlpha(b)
+			if c := strings.Compare(aAlpha, bAlpha); c != 0 {
+				if aAlpha == "" {
+					return 1
+				}
+				if bAlpha == "" {
+					return -1
+				}
+				return c
+			}
+			a = a[len(aAlpha):]
+			b = b[len(bAlpha):]
+			if aAlpha == "" && bAlpha == "" {
+				// Only separators were left on both sides, so a and b are
+				// now empty and the loop condition ends the walk; this
+				// break leaves only the switch.
+				break
+			}
+		}
+	}
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
+		return -1
+	default:
+		return 0
+	}
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+func leadingAlpha(s string) string {
+	i := 0
+	for i < len(s) && !(s[i] >= '0' && s[i] <= '9') && s[i] != '.' && s[i] != '-' && s[i] != '_' && s[i] != '+' {
+		i++
+	}
+	return s[:i]
+}
+
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
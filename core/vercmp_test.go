@@ -0,0 +1,51 @@
+package core
+
+import "testing"
+
+func TestVerCmp(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.1", "1.0", 1},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-2", "1.0-1", 1},
+		{"1.0", "1.0-1", 0},
+		{"1:1.0", "2.0", 1},
+		{"1:1.0", "1:2.0", -1},
+		{"0:1.0", "1.0", 0},
+		{"1.0alpha", "1.0", -1},
+		{"1.0", "1.0alpha", 1},
+		{"1.0alpha1", "1.0alpha2", -1},
+		{"1.0.a", "1.0.alpha", -1},
+		{"1.011", "1.1", 1},
+		{"1.001", "1.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1", "2.0", 1},
+		{"1.2.3", "1.2.3", 0},
+	}
+
+	for _, c := range cases {
+		if got := VerCmp(c.a, c.b); got != c.want {
+			t.Errorf("VerCmp(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestVerCmpAntisymmetric(t *testing.T) {
+	pairs := [][2]string{
+		{"1.0", "1.1"},
+		{"1.0-1", "1.0-2"},
+		{"1:1.0", "2.0"},
+		{"1.0alpha1", "1.0alpha2"},
+	}
+	for _, p := range pairs {
+		fwd := VerCmp(p[0], p[1])
+		rev := VerCmp(p[1], p[0])
+		if fwd != -rev {
+			t.Errorf("VerCmp(%q, %q) = %d, VerCmp(%q, %q) = %d, not antisymmetric", p[0], p[1], fwd, p[1], p[0], rev)
+		}
+	}
+}
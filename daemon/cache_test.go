@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"upkg/core"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "status.json")
+
+	want := Snapshot{
+		GeneratedAt:    time.Date(2026, 7, 28, 12, 0, 0, 0, time.UTC),
+		LastSyncTime:   time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC),
+		TotalInstalled: 42,
+		PendingByRepo:  map[string]int{"core": 2, "aur": 1},
+		Upgrades: []UpgradeEntry{
+			{Name: "foo", OldVersion: "1.0-1", NewVersion: "1.1-1", Repo: "core"},
+		},
+	}
+
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if !got.GeneratedAt.Equal(want.GeneratedAt) {
+		t.Errorf("GeneratedAt = %v, want %v", got.GeneratedAt, want.GeneratedAt)
+	}
+	if !got.LastSyncTime.Equal(want.LastSyncTime) {
+		t.Errorf("LastSyncTime = %v, want %v", got.LastSyncTime, want.LastSyncTime)
+	}
+	if got.TotalInstalled != want.TotalInstalled {
+		t.Errorf("TotalInstalled = %d, want %d", got.TotalInstalled, want.TotalInstalled)
+	}
+	if len(got.Upgrades) != 1 || got.Upgrades[0] != want.Upgrades[0] {
+		t.Errorf("Upgrades = %+v, want %+v", got.Upgrades, want.Upgrades)
+	}
+	if got.PendingByRepo["core"] != 2 || got.PendingByRepo["aur"] != 1 {
+		t.Errorf("PendingByRepo = %v, want map[aur:1 core:2]", got.PendingByRepo)
+	}
+}
+
+func TestSnapshotCoreDataCarriesMissingOrphanedOutOfDate(t *testing.T) {
+	snap := Snapshot{
+		LastSyncTime:    time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC),
+		MissingByRepo:   map[string]int{"apt": 3},
+		OrphanedByRepo:  map[string]int{"pacman": 2},
+		OutOfDateByRepo: map[string]int{"pacman": 1},
+		Upgrades: []UpgradeEntry{
+			{Name: "foo", OldVersion: "1.0-1", NewVersion: "1.1-1", Repo: "core"},
+		},
+	}
+
+	data := snap.CoreData()
+
+	byName := make(map[string]core.ProviderData)
+	for _, pd := range data.Providers {
+		byName[pd.Name] = pd
+	}
+
+	if byName["apt"].Missing != 3 {
+		t.Errorf("apt.Missing = %d, want 3", byName["apt"].Missing)
+	}
+	if byName["pacman"].Orphaned != 2 {
+		t.Errorf("pacman.Orphaned = %d, want 2", byName["pacman"].Orphaned)
+	}
+	if byName["pacman"].OutOfDate != 1 {
+		t.Errorf("pacman.OutOfDate = %d, want 1", byName["pacman"].OutOfDate)
+	}
+	if byName["core"].Pending != 1 {
+		t.Errorf("core.Pending = %d, want 1", byName["core"].Pending)
+	}
+}
+
+func TestSnapshotCoreDataProvidersSorted(t *testing.T) {
+	snap := Snapshot{
+		MissingByRepo:   map[string]int{"zeta": 1},
+		OrphanedByRepo:  map[string]int{"alpha": 1},
+		OutOfDateByRepo: map[string]int{"mid": 1},
+	}
+
+	data := snap.CoreData()
+
+	var names []string
+	for _, pd := range data.Providers {
+		names = append(names, pd.Name)
+	}
+	want := []string{"alpha", "mid", "zeta"}
+	if len(names) != len(want) {
+		t.Fatalf("Providers names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Providers[%d].Name = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func TestSaveOverwritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "status.json")
+
+	first := Snapshot{TotalInstalled: 1, PendingByRepo: map[string]int{}}
+	second := Snapshot{TotalInstalled: 2, PendingByRepo: map[string]int{}}
+
+	if err := Save(path, first); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := Save(path, second); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got.TotalInstalled != 2 {
+		t.Errorf("TotalInstalled = %d, want 2 (second Save should win)", got.TotalInstalled)
+	}
+
+	// No leftover temp files from the rename-into-place.
+	matches, err := filepath.Glob(filepath.Join(dir, ".status-*.json.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("leftover temp files: %v", matches)
+	}
+}
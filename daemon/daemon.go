@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"time"
+
+	"upkg/core"
+)
+
+// DefaultInterval is how often Run refreshes the cache when no interval
+// is configured.
+const DefaultInterval = 6 * time.Hour
+
+// DefaultTTL is how stale a cached snapshot is allowed to be before
+// Status falls back to a synchronous refresh.
+const DefaultTTL = DefaultInterval
+
+// Refresh queries every active provider via core.GetCoreDate, writes the
+// result to the on-disk cache, and returns the CoreData it gathered.
+func Refresh() (core.CoreData, error) {
+	data := core.GetCoreDate()
+
+	path, err := CachePath()
+	if err != nil {
+		return data, err
+	}
+	if err := Save(path, newSnapshot(data)); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// Status returns the current update status, preferring the on-disk
+// cache when it exists and is younger than ttl. Pass force to bypass the
+// cache entirely and always query providers live.
+func Status(force bool, ttl time.Duration) (core.CoreData, error) {
+	if !force {
+		if path, err := CachePath(); err == nil {
+			if snap, err := Load(path); err == nil && time.Since(snap.GeneratedAt) < ttl {
+				return snap.CoreData(), nil
+			}
+		}
+	}
+	data, err := Refresh()
+	return data, err
+}
+
+// Run refreshes the cache once immediately and then every interval,
+// blocking until stop is closed; one-off invocations should call Status
+// instead.
+func Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	Refresh()
+	for {
+		select {
+		case <-ticker.C:
+			Refresh()
+		case <-stop:
+			return
+		}
+	}
+}
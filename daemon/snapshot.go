@@ -0,0 +1,134 @@
+// Package daemon periodically refreshes core.CoreData in the background
+// and persists it to a small on-disk cache, so invoking upkg from a
+// shell prompt or status bar is cheap instead of re-querying every
+// provider on every call.
+package daemon
+
+import (
+	"sort"
+	"time"
+
+	"upkg/core"
+)
+
+// UpgradeEntry is one pending upgrade as recorded in a Snapshot.
+type UpgradeEntry struct {
+	Name       string `json:"name"`
+	OldVersion string `json:"oldver"`
+	NewVersion string `json:"newver"`
+	Repo       string `json:"repo"`
+}
+
+// Snapshot is the JSON document written to the on-disk cache.
+type Snapshot struct {
+	GeneratedAt    time.Time      `json:"generated_at"`
+	LastSyncTime   time.Time      `json:"last_sync_time"`
+	TotalInstalled int            `json:"total_installed"`
+	PendingByRepo  map[string]int `json:"pending_by_repo"`
+	Upgrades       []UpgradeEntry `json:"upgrades"`
+	// MissingByRepo, OrphanedByRepo, and OutOfDateByRepo carry the
+	// per-provider counts ui.PrintWarnings needs, keyed by provider name
+	// (ProviderData.Name) rather than by package repository like
+	// PendingByRepo/Upgrades, since those counts are provider-wide rather
+	// than tied to any single pending upgrade.
+	MissingByRepo   map[string]int `json:"missing_by_repo,omitempty"`
+	OrphanedByRepo  map[string]int `json:"orphaned_by_repo,omitempty"`
+	OutOfDateByRepo map[string]int `json:"outofdate_by_repo,omitempty"`
+}
+
+// newSnapshot captures a core.CoreData query as a Snapshot.
+func newSnapshot(data core.CoreData) Snapshot {
+	snap := Snapshot{
+		GeneratedAt:     now(),
+		TotalInstalled:  data.TotalPackagesInstalled,
+		PendingByRepo:   make(map[string]int),
+		MissingByRepo:   make(map[string]int),
+		OrphanedByRepo:  make(map[string]int),
+		OutOfDateByRepo: make(map[string]int),
+	}
+
+	for _, pd := range data.Providers {
+		if pd.LastSync.After(snap.LastSyncTime) {
+			snap.LastSyncTime = pd.LastSync
+		}
+		if pd.Missing > 0 {
+			snap.MissingByRepo[pd.Name] = pd.Missing
+		}
+		if pd.Orphaned > 0 {
+			snap.OrphanedByRepo[pd.Name] = pd.Orphaned
+		}
+		if pd.OutOfDate > 0 {
+			snap.OutOfDateByRepo[pd.Name] = pd.OutOfDate
+		}
+		for _, pkg := range pd.Upgrades {
+			snap.PendingByRepo[pkg.Repository]++
+			snap.Upgrades = append(snap.Upgrades, UpgradeEntry{
+				Name:       pkg.Name,
+				OldVersion: pkg.LocalVersion,
+				NewVersion: pkg.RemoteVersion,
+				Repo:       pkg.Repository,
+			})
+		}
+	}
+	return snap
+}
+
+// CoreData reconstructs the subset of core.CoreData that ui rendering
+// needs from a cached Snapshot, without re-querying any provider.
+func (s Snapshot) CoreData() core.CoreData {
+	data := core.CoreData{
+		TotalPackagesInstalled: s.TotalInstalled,
+		DaysSinceUpdate:        core.DaysSince(s.LastSyncTime),
+	}
+	if s.LastSyncTime.IsZero() {
+		data.DaysSinceUpdate = 0
+	}
+
+	byRepo := make(map[string]*core.ProviderData)
+	getOrCreate := func(name string) *core.ProviderData {
+		pd, ok := byRepo[name]
+		if !ok {
+			pd = &core.ProviderData{Name: name, LastSync: s.LastSyncTime}
+			byRepo[name] = pd
+		}
+		return pd
+	}
+
+	for _, u := range s.Upgrades {
+		pd := getOrCreate(u.Repo)
+		pd.Pending++
+		pd.Upgrades = append(pd.Upgrades, core.Package{
+			Name:          u.Name,
+			Repository:    u.Repo,
+			LocalVersion:  u.OldVersion,
+			RemoteVersion: u.NewVersion,
+		})
+		data.PendingUpdates++
+	}
+	for name, n := range s.MissingByRepo {
+		getOrCreate(name).Missing = n
+	}
+	for name, n := range s.OrphanedByRepo {
+		getOrCreate(name).Orphaned = n
+	}
+	for name, n := range s.OutOfDateByRepo {
+		getOrCreate(name).OutOfDate = n
+	}
+
+	// Sorted so repeated invocations from a shell prompt/status bar don't
+	// reorder providers/warnings on every run just because Go randomizes
+	// map iteration order.
+	names := make([]string, 0, len(byRepo))
+	for name := range byRepo {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data.Providers = append(data.Providers, *byRepo[name])
+	}
+	return data
+}
+
+// now is a var rather than a direct time.Now() call so tests can stub it;
+// kept here rather than in cache.go since GeneratedAt is a Snapshot field.
+var now = time.Now
@@ -0,0 +1,75 @@
+// Package intrange parses the compact index-selection grammar used by
+// ui.SelectUpgrades: space-separated tokens that are either a single
+// index ("4"), an inclusive range ("1-3"), or a negated form of either
+// ("^2", "^5-7") that re-includes previously excluded indices.
+package intrange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IntRangeSet is the result of parsing a selection string: the set of
+// indices it marks as excluded.
+type IntRangeSet struct {
+	excluded map[int]bool
+}
+
+// Get reports whether n was excluded.
+func (s IntRangeSet) Get(n int) bool {
+	return s.excluded[n]
+}
+
+// Parse reads a selection string and returns the resulting IntRangeSet.
+// Tokens are applied left to right, so a later "^" token can re-include
+// an index excluded by an earlier one. Out-of-bound indices are recorded
+// like any other and simply never match a Get call within range.
+func Parse(input string) (IntRangeSet, error) {
+	set := IntRangeSet{excluded: make(map[int]bool)}
+
+	for _, tok := range strings.Fields(input) {
+		negate := strings.HasPrefix(tok, "^")
+		if negate {
+			tok = tok[1:]
+		}
+
+		lo, hi, err := parseToken(tok)
+		if err != nil {
+			return set, fmt.Errorf("intrange: invalid token %q: %w", tok, err)
+		}
+		if hi < lo {
+			lo, hi = hi, lo
+		}
+
+		for i := lo; i <= hi; i++ {
+			if negate {
+				delete(set.excluded, i)
+			} else {
+				set.excluded[i] = true
+			}
+		}
+	}
+
+	return set, nil
+}
+
+func parseToken(tok string) (lo, hi int, err error) {
+	if i := strings.IndexByte(tok, '-'); i > 0 {
+		lo, err = strconv.Atoi(tok[:i])
+		if err != nil {
+			return 0, 0, err
+		}
+		hi, err = strconv.Atoi(tok[i+1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		return lo, hi, nil
+	}
+
+	n, err := strconv.Atoi(tok)
+	if err != nil {
+		return 0, 0, err
+	}
+	return n, n, nil
+}
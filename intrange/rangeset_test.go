@@ -0,0 +1,57 @@
+package intrange
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		excluded []int
+		included []int
+	}{
+		{"", nil, []int{1, 2, 3}},
+		{"2", []int{2}, []int{1, 3}},
+		{"1-3", []int{1, 2, 3}, nil},
+		{"3-1", []int{1, 2, 3}, nil},
+		{"1-3 ^2", []int{1, 3}, []int{2}},
+		{"1 2 3 ^2", []int{1, 3}, []int{2}},
+		{"1-3 ^2-3", []int{1}, []int{2, 3}},
+	}
+
+	for _, c := range cases {
+		set, err := Parse(c.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", c.input, err)
+		}
+		for _, n := range c.excluded {
+			if !set.Get(n) {
+				t.Errorf("Parse(%q): expected %d to be excluded", c.input, n)
+			}
+		}
+		for _, n := range c.included {
+			if set.Get(n) {
+				t.Errorf("Parse(%q): expected %d to not be excluded", c.input, n)
+			}
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := []string{"x", "1-", "-1-2", "a-b"}
+	for _, input := range cases {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", input)
+		}
+	}
+}
+
+func TestParseOrderMatters(t *testing.T) {
+	// Tokens apply left to right: a later plain token excludes again an
+	// index that an earlier "^" token re-included.
+	set, err := Parse("^2 2")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if !set.Get(2) {
+		t.Error("expected 2 to be excluded after re-applying it without negation")
+	}
+}
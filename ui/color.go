@@ -0,0 +1,41 @@
+package ui
+
+import "os"
+
+const (
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiRed     = "\033[31m"
+	ansiGreen   = "\033[32m"
+	ansiMagenta = "\033[35m"
+	ansiCyan    = "\033[36m"
+)
+
+// colorEnabled decides once, at package init, whether ANSI escapes
+// should be emitted: disabled if NO_COLOR is set (https://no-color.org/)
+// or if stdout isn't a terminal.
+var colorEnabled = detectColor()
+
+func detectColor() bool {
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func colorize(code, s string) string {
+	if !colorEnabled {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func Bold(s string) string    { return colorize(ansiBold, s) }
+func Red(s string) string     { return colorize(ansiRed, s) }
+func Green(s string) string   { return colorize(ansiGreen, s) }
+func Magenta(s string) string { return colorize(ansiMagenta, s) }
+func Cyan(s string) string    { return colorize(ansiCyan, s) }
@@ -2,33 +2,126 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
 	"upkg/core"
 )
 
-func DisplayCoreData(data core.CoreData) {
-	fmt.Println("───────── System Update Status ─────────")
-	fmt.Printf("Days Since Last Update: %d\n", data.DaysSinceUpdate)
-	fmt.Printf("Total Packages Installed: %d\n", data.TotalPackagesInstalled)
-	fmt.Printf("Pending Updates: %d\n", data.PendingUpdates)
+// DisplayOptions controls how DisplayCoreData renders.
+type DisplayOptions struct {
+	// Minimal prints one package per line with no decoration, suited to
+	// scripting and status-bar integrations.
+	Minimal bool
+}
 
-	barWidth := 20
-	filled := data.PendingUpdates
-	if filled > barWidth {
-		filled = barWidth
+// DisplayCoreData renders a CoreData snapshot either as a single
+// box-drawn dashboard or, in minimal mode, as plain scriptable lines.
+func DisplayCoreData(data core.CoreData, opts DisplayOptions) {
+	if opts.Minimal {
+		displayMinimal(data)
+	} else {
+		displayFull(data)
 	}
-	empty := barWidth - filled
+	PrintWarnings(data)
+}
+
+func displayMinimal(data core.CoreData) {
+	for _, upgrade := range core.PendingUpgrades(data) {
+		fmt.Printf("%s %s %s %s\n", upgrade.Name, upgrade.Repository, upgrade.LocalVersion, upgrade.RemoteVersion)
+	}
+}
+
+const boxWidth = 41
 
-	bar := fmt.Sprintf("[%s%s]", repeat("█", filled), repeat("░", empty))
-	fmt.Printf("Updates available      : %s\n", bar)
-	fmt.Println("───────────────────────────────────────")
+func displayFull(data core.CoreData) {
+	upgrades := core.PendingUpgrades(data)
+	var downloadSize, installedSize int64
+	for _, u := range upgrades {
+		downloadSize += u.DownloadSize
+		installedSize += u.InstalledSize
+	}
 
+	printBoxTop(T("System Update Status"))
+	printBoxLine(T("Days Since Last Update:   %d", data.DaysSinceUpdate))
+	printBoxLine(T("Total Packages Installed: %d", data.TotalPackagesInstalled))
+	printBoxLine(colorCount(data.PendingUpdates))
+	if downloadSize > 0 {
+		printBoxLine(T("Download Size:            %s", FormatSize(downloadSize)))
+		printBoxLine(T("Installed Size:           %s", FormatSize(installedSize)))
+	}
+	printBoxLine("")
+	printBoxLine(T("Updates available: %s", pendingGauge(data.PendingUpdates, data.TotalPackagesInstalled)))
+	printBoxBottom()
 }
 
+// pendingGauge renders a 20-cell bar proportional to pending/total,
+// rather than one cell per pending update, so the gauge stays readable
+// even when pending count exceeds the bar width.
+func pendingGauge(pending, total int) string {
+	const width = 20
+	filled := 0
+	if total > 0 {
+		filled = pending * width / total
+	}
+	if filled > width {
+		filled = width
+	}
+	if filled == 0 && pending > 0 {
+		filled = 1
+	}
+
+	var b strings.Builder
+	b.WriteByte('[')
+	b.WriteString(Green(repeat("█", filled)))
+	b.WriteString(repeat("░", width-filled))
+	b.WriteByte(']')
+	return b.String()
+}
 
+// repeat concatenates n copies of s via a strings.Builder, which avoids
+// the repeated string reallocation of naive "+=" concatenation.
 func repeat(s string, n int) string {
-	result := ""
+	var b strings.Builder
+	b.Grow(len(s) * n)
 	for i := 0; i < n; i++ {
-		result += s
+		b.WriteString(s)
 	}
-	return result
-}	
+	return b.String()
+}
+
+func colorCount(n int) string {
+	text := TN("%d pending update", "%d pending updates", n, n)
+	if n == 0 {
+		return Green(text)
+	}
+	return Red(text)
+}
+
+func printBoxTop(title string) {
+	pad := boxWidth - visibleLen(title) - 2
+	left := pad / 2
+	right := pad - left
+	fmt.Printf("╭%s %s %s╮\n", repeat("─", left), Cyan(Bold(title)), repeat("─", right))
+}
+
+// ansiEscape matches the SGR color/style sequences Bold/Red/Green/etc.
+// emit, so printBoxLine can pad on visible width rather than byte length.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+func printBoxLine(s string) {
+	pad := boxWidth - 2 - visibleLen(s)
+	if pad < 0 {
+		pad = 0
+	}
+	fmt.Printf("│ %s%s │\n", s, repeat(" ", pad))
+}
+
+func printBoxBottom() {
+	fmt.Printf("╰%s╯\n", repeat("─", boxWidth))
+}
@@ -0,0 +1,20 @@
+package ui
+
+import "fmt"
+
+var sizeUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+// FormatSize renders n bytes using 1024-based unit scaling, e.g.
+// FormatSize(13006109) == "12.4 MiB".
+func FormatSize(n int64) string {
+	size := float64(n)
+	unit := 0
+	for size >= 1024 && unit < len(sizeUnits)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", n, sizeUnits[unit])
+	}
+	return fmt.Sprintf("%.1f %s", size, sizeUnits[unit])
+}
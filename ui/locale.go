@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"embed"
+	"os"
+	"strings"
+
+	"github.com/leonelquinteros/gotext"
+)
+
+//go:embed locale
+var localeFS embed.FS
+
+const textDomain = "upkg"
+
+// catalog is the active translator; InitLocale swaps it out once the
+// user's locale has been detected. It defaults to an empty Locale so
+// T/TN degrade to returning their input verbatim if InitLocale is never
+// called (e.g. in tests).
+var catalog = gotext.NewLocale("", "en")
+
+// InitLocale detects the active locale the same way gettext does --
+// LC_ALL, then LC_MESSAGES, then LANG, in priority order -- falling back
+// to English, and loads the matching embedded .po catalog for
+// textDomain so every ui.T/ui.TN call renders in that language.
+func InitLocale() {
+	lang := detectLocale()
+
+	locale := gotext.NewLocale("", lang)
+	if data, err := localeFS.ReadFile("locale/" + lang + "/LC_MESSAGES/" + textDomain + ".po"); err == nil {
+		po := gotext.NewPo()
+		po.Parse(data)
+		locale.AddTranslator(textDomain, po)
+	}
+	catalog = locale
+}
+
+// detectLocale mirrors glibc's gettext lookup order, trimming encoding
+// suffixes like ".UTF-8" and territory variants ("es_ES" -> "es") down
+// to the bare language code our catalogs are keyed by.
+func detectLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" && v != "C" && v != "POSIX" {
+			v = strings.SplitN(v, ".", 2)[0]
+			v = strings.SplitN(v, "_", 2)[0]
+			return v
+		}
+	}
+	return "en"
+}
+
+// T looks up str in the active catalog and formats the result with vars,
+// the way fmt.Sprintf would. Every user-facing string in ui routes
+// through T or TN instead of fmt.Sprintf directly so translators can
+// contribute without touching Go code.
+func T(str string, vars ...interface{}) string {
+	return catalog.Get(str, vars...)
+}
+
+// TN is the plural-aware counterpart of T, picking the singular or
+// plural form based on n the way "%d pending update(s)" needs to across
+// languages with different pluralization rules.
+func TN(str, plural string, n int, vars ...interface{}) string {
+	return catalog.GetN(str, plural, n, vars...)
+}
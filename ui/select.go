@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"upkg/core"
+	"upkg/intrange"
+)
+
+// SelectUpgrades lists every pending upgrade, numbered top-down and
+// sorted by repository, then prompts the user to exclude entries using
+// the intrange grammar before returning the upgrades that should still
+// be applied.
+func SelectUpgrades(upgrades []core.Upgrade) []core.Upgrade {
+	if len(upgrades) == 0 {
+		fmt.Println(T("No upgrades available."))
+		return nil
+	}
+
+	sorted := make([]core.Upgrade, len(upgrades))
+	copy(sorted, upgrades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Repository < sorted[j].Repository
+	})
+
+	for i, u := range sorted {
+		fmt.Printf("%3d  %s %s %s %s\n",
+			i+1, padVisible(Magenta(u.Repository), 10), padVisible(u.Name, 30),
+			padVisible(diffVersion(u.LocalVersion, u.RemoteVersion), 40), FormatSize(u.DownloadSize))
+	}
+
+	fmt.Print(T("Packages to exclude (e.g. \"1-3 5 ^2\"), or enter to upgrade all: "))
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	excluded, err := intrange.Parse(strings.TrimSpace(line))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, T("ignoring invalid selection (%v), upgrading everything", err))
+		return sorted
+	}
+
+	selected := make([]core.Upgrade, 0, len(sorted))
+	for i, u := range sorted {
+		if !excluded.Get(i + 1) {
+			selected = append(selected, u)
+		}
+	}
+	return selected
+}
+
+// diffVersion renders "local -> remote", bolding the remote version's
+// suffix starting at the first rune that differs from local so the part
+// of the bump that actually changed stands out, the way yay does.
+func diffVersion(local, remote string) string {
+	common := commonPrefixLen(local, remote)
+	rr := []rune(remote)
+	return fmt.Sprintf("%s -> %s%s", local, string(rr[:common]), Bold(string(rr[common:])))
+}
+
+// padVisible right-pads s to width columns, measuring by visibleLen
+// rather than raw byte/rune length so ANSI color codes (as emitted by
+// Magenta/Bold above) don't throw off the table's column alignment the
+// way they would with a plain fmt width specifier.
+func padVisible(s string, width int) string {
+	pad := width - visibleLen(s)
+	if pad < 0 {
+		pad = 0
+	}
+	return s + repeat(" ", pad)
+}
+
+func commonPrefixLen(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	n := len(ar)
+	if len(br) < n {
+		n = len(br)
+	}
+	i := 0
+	for i < n && ar[i] == br[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"upkg/core"
+)
+
+// PrintWarnings reports, per provider, the package breakdown that isn't
+// already covered by the upgrade listing: packages missing from the
+// provider's index entirely, packages orphaned (installed only as a
+// dependency and no longer required), and packages flagged out of date.
+// Every message routes through the locale catalog like the rest of ui.
+func PrintWarnings(data core.CoreData) {
+	for _, pd := range data.Providers {
+		if pd.Missing > 0 {
+			fmt.Fprintln(os.Stderr, Magenta(TN(
+				"%s: %d package not found upstream",
+				"%s: %d packages not found upstream",
+				pd.Missing, pd.Name, pd.Missing)))
+		}
+		if pd.Orphaned > 0 {
+			fmt.Fprintln(os.Stderr, Magenta(TN(
+				"%s: %d orphaned package (no longer required)",
+				"%s: %d orphaned packages (no longer required)",
+				pd.Orphaned, pd.Name, pd.Orphaned)))
+		}
+		if pd.OutOfDate > 0 {
+			fmt.Fprintln(os.Stderr, Cyan(TN(
+				"%s: %d package out of date",
+				"%s: %d packages out of date",
+				pd.OutOfDate, pd.Name, pd.OutOfDate)))
+		}
+	}
+}